@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"html/template"
 	"log"
@@ -9,16 +11,38 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/iljo-dp/htmx-go-binairo/pkg/binairo"
 )
 
-// Cell represents a single cell in the grid.
-type Cell struct {
-	Value    *int `json:"value"`
-	ReadOnly bool `json:"readonly"` // Added readonly flag
-}
+// maxGridSize bounds how large a board a client can request. Without a cap,
+// a large empty grid can pin a CPU core for minutes.
+const maxGridSize = 20
 
-// Grid represents the puzzle grid.
-type Grid [][]*Cell
+// solveTimeout and generateTimeout bound how long a single request is
+// allowed to spend inside the solver before the handler gives up and
+// reports a clean timeout instead of hanging.
+const (
+	solveTimeout    = 5 * time.Second
+	generateTimeout = 15 * time.Second
+)
+
+// parseGridSize reads and validates the gridSize form value shared by every
+// handler: it must be a positive, even number no larger than maxGridSize,
+// since Binairo requires an even side length.
+func parseGridSize(r *http.Request) (int, error) {
+	size, err := strconv.Atoi(r.FormValue("gridSize"))
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("invalid grid size")
+	}
+	if size > maxGridSize {
+		return 0, fmt.Errorf("grid size must be %d or smaller", maxGridSize)
+	}
+	if size%2 != 0 {
+		return 0, fmt.Errorf("grid size must be even")
+	}
+	return size, nil
+}
 
 func main() {
 	http.HandleFunc("/", indexHandler)
@@ -45,39 +69,48 @@ func solveHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gridSizeStr := r.FormValue("gridSize")
-	size, err := strconv.Atoi(gridSizeStr)
-	if err != nil || size <= 0 {
+	size, err := parseGridSize(r)
+	if err != nil {
 		log.Printf("Invalid grid size: %v\n", err)
-		http.Error(w, "Invalid grid size", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	log.Printf("Grid size: %d\n", size)
 
-	grid := make(Grid, size)
-	for i := range grid {
-		grid[i] = make([]*Cell, size)
-		for j := range grid[i] {
+	board := binairo.New(size)
+	given := make([][]bool, size)
+	for i := range given {
+		given[i] = make([]bool, size)
+		for j := range given[i] {
 			cellName := fmt.Sprintf("cell-%d-%d", i, j)
 			cellValue := r.FormValue(cellName)
 			if cellValue == "" {
-				grid[i][j] = &Cell{Value: nil}
-			} else {
-				val, err := strconv.Atoi(cellValue)
-				if err != nil {
-					log.Printf("Invalid cell value %s: %v\n", cellValue, err)
-					http.Error(w, "Invalid cell value", http.StatusBadRequest)
-					return
-				}
-				grid[i][j] = &Cell{Value: &val, ReadOnly: true}
+				continue
+			}
+			val, err := strconv.Atoi(cellValue)
+			if err != nil {
+				log.Printf("Invalid cell value %s: %v\n", cellValue, err)
+				http.Error(w, "Invalid cell value", http.StatusBadRequest)
+				return
 			}
+			board.Set(i, j, val)
+			given[i][j] = true
 		}
 	}
 
 	log.Println("Grid received, solving...")
-	solvedGrid := solveBinairo(grid)
-	response := renderSolvedGridHTML(solvedGrid)
+	ctx, cancel := context.WithTimeout(r.Context(), solveTimeout)
+	defer cancel()
+	if err := board.Solve(ctx); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			log.Printf("Solve timed out: %v\n", err)
+			http.Error(w, "Puzzle too hard to solve in time, try a smaller size", http.StatusServiceUnavailable)
+			return
+		}
+		log.Printf("Could not solve puzzle: %v\n", err)
+	}
+	response := renderBoardHTML(board, given, nil)
 	w.Header().Set("Content-Type", "text/html")
 	fmt.Fprintln(w, response)
 	log.Println("Puzzle solved, response sent")
@@ -93,57 +126,55 @@ func generateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	gridSizeStr := r.FormValue("gridSize")
-	size, err := strconv.Atoi(gridSizeStr)
-	if err != nil || size <= 0 {
+	size, err := parseGridSize(r)
+	if err != nil {
 		log.Printf("Invalid grid size: %v\n", err)
-		http.Error(w, "Invalid grid size", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	grid := make(Grid, size)
-	for i := range grid {
-		grid[i] = make([]*Cell, size)
-		for j := range grid[i] {
-			grid[i][j] = &Cell{Value: nil}
-		}
+	difficulty := binairo.Difficulty(r.FormValue("difficulty"))
+	switch difficulty {
+	case binairo.DifficultyEasy, binairo.DifficultyMedium, binairo.DifficultyHard:
+	default:
+		difficulty = binairo.DifficultyMedium
 	}
 
-	// Solve the grid first
-	solveBinairo(grid)
-
-	// Remove some cells to create the puzzle
 	rand.Seed(time.Now().UnixNano())
-	numCellsToRemove := (size * size) / 2
-	for numCellsToRemove > 0 {
-		i, j := rand.Intn(size), rand.Intn(size)
-		if grid[i][j].Value != nil {
-			grid[i][j].Value = nil      // Empty the cell for user to fill
-			grid[i][j].ReadOnly = false // Set as editable
-			numCellsToRemove--
-		}
+	ctx, cancel := context.WithTimeout(r.Context(), generateTimeout)
+	defer cancel()
+	board, err := binairo.Generate(ctx, size, difficulty)
+	if err != nil {
+		log.Printf("Failed to generate puzzle: %v\n", err)
+		http.Error(w, "Puzzle generation timed out, try a smaller size or lower difficulty", http.StatusServiceUnavailable)
+		return
 	}
 
-	// Mark the remaining cells as readonly (pre-filled)
-	for i := range grid {
-		for j := range grid[i] {
-			if grid[i][j].Value != nil {
-				grid[i][j].ReadOnly = true // Pre-filled cells are readonly
-			}
+	// Every clue Generate left behind is read-only; the cells it removed are
+	// what the player fills in.
+	given := make([][]bool, size)
+	for i := range given {
+		given[i] = make([]bool, size)
+		for j := range given[i] {
+			_, ok := board.Value(i, j)
+			given[i][j] = ok
 		}
 	}
 
-	response := renderSolvedGridHTML(grid)
+	response := renderBoardHTML(board, given, nil)
 	w.Header().Set("Content-Type", "text/html")
 	fmt.Fprintln(w, response)
 	log.Println("Puzzle generated, response sent")
 }
 
-// validateHandler checks if the user's solution is valid.
+// validateHandler checks the user's in-progress grid and returns an
+// htmx-swappable HTML fragment with every rule-breaking cell tagged, so the
+// UI can highlight individual cells instead of just showing one verdict.
+// Cells that are still empty are simply skipped rather than rejecting the
+// whole grid, so the player gets feedback while solving, not only at the end.
 func validateHandler(w http.ResponseWriter, r *http.Request) {
 	log.Println("Validate request received")
 
-	// Parse form data
 	err := r.ParseForm()
 	if err != nil {
 		log.Printf("Error parsing form: %v\n", err)
@@ -151,27 +182,22 @@ func validateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get and validate grid size
-	gridSizeStr := r.FormValue("gridSize")
-	size, err := strconv.Atoi(gridSizeStr)
-	if err != nil || size <= 0 {
+	size, err := parseGridSize(r)
+	if err != nil {
 		log.Printf("Invalid grid size: %v\n", err)
-		http.Error(w, "Invalid grid size", http.StatusBadRequest)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	log.Printf("Grid size: %d\n", size)
 
-	grid := make(Grid, size)
-	for i := range grid {
-		grid[i] = make([]*Cell, size)
-		for j := range grid[i] {
+	board := binairo.New(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
 			cellName := fmt.Sprintf("cell-%d-%d", i, j)
 			cellValue := r.FormValue(cellName)
 			if cellValue == "" {
-				log.Println("Grid is not fully filled in")
-				fmt.Fprintln(w, "Grid is not fully filled in")
-				return
+				continue
 			}
 			val, err := strconv.Atoi(cellValue)
 			if err != nil {
@@ -179,140 +205,62 @@ func validateHandler(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, "Invalid cell value", http.StatusBadRequest)
 				return
 			}
-			grid[i][j] = &Cell{Value: &val}
+			board.Set(i, j, val)
 		}
 	}
 
-	// Validate the grid
-	valid := true
-	for i := 0; i < size; i++ {
-		for j := 0; j < size; j++ {
-			if !isValid(grid, i, j, *grid[i][j].Value) {
-				valid = false
-				break
-			}
-		}
-		if !valid {
-			break
-		}
-	}
-
-	if valid {
-		log.Println("Grid is valid")
-		fmt.Fprintln(w, "valid")
-	} else {
-		log.Println("Grid is invalid")
-		fmt.Fprintln(w, "invalid")
-	}
-}
-
-// solveBinairo implements the Binairo solving algorithm.
-func solveBinairo(grid Grid) Grid {
-	size := len(grid)
-	var solve func() bool
-	solve = func() bool {
-		for i := 0; i < size; i++ {
-			for j := 0; j < size; j++ {
-				if grid[i][j].Value == nil {
-					for _, val := range []int{0, 1} {
-						if isValid(grid, i, j, val) {
-							grid[i][j].Value = &val
-							if solve() {
-								return true
-							}
-							grid[i][j].Value = nil
-						}
-					}
-					return false
-				}
-			}
-		}
-		return true
-	}
-	solve()
-	return grid
-}
-
-// isValid checks if placing a value at grid[row][col] is valid.
-func isValid(grid Grid, row, col, value int) bool {
-	size := len(grid)
-	rowValues := make([]int, size)
-	colValues := make([]int, size)
-
-	for i := 0; i < size; i++ {
-		if grid[row][i].Value != nil {
-			rowValues[i] = *grid[row][i].Value
-		} else {
-			rowValues[i] = -1
-		}
-		if grid[i][col].Value != nil {
-			colValues[i] = *grid[i][col].Value
-		} else {
-			colValues[i] = -1
-		}
-	}
+	violations := board.Violations()
+	log.Printf("Validation found %d violation(s)\n", len(violations))
 
-	rowValues[col] = value
-	colValues[row] = value
-
-	if countOccurrences(rowValues, value) > size/2 || countOccurrences(colValues, value) > size/2 {
-		return false
-	}
-
-	if (col > 1 && isSame(rowValues[col-2:col+1], []int{value, value, value})) ||
-		(col < size-2 && isSame(rowValues[col:col+3], []int{value, value, value})) ||
-		(row > 1 && isSame(colValues[row-2:row+1], []int{value, value, value})) ||
-		(row < size-2 && isSame(colValues[row:row+3], []int{value, value, value})) {
-		return false
-	}
-
-	return true
-}
-
-// countOccurrences counts the occurrences of a value in a slice.
-func countOccurrences(slice []int, value int) int {
-	count := 0
-	for _, v := range slice {
-		if v == value {
-			count++
-		}
-	}
-	return count
+	response := renderBoardHTML(board, nil, violations)
+	w.Header().Set("Content-Type", "text/html")
+	fmt.Fprintln(w, response)
 }
 
-// isSame checks if two slices are equal in terms of elements and their order.
-func isSame(a, b []int) bool {
-	if len(a) != len(b) {
-		return false
-	}
-	for i := range a {
-		if a[i] != b[i] {
-			return false
+// violationKinds lists binairo's violation kinds in the order they're
+// applied to a cell's CSS classes.
+var violationKinds = []string{binairo.ViolationTriple, binairo.ViolationBalance, binairo.ViolationDuplicateLine}
+
+// renderBoardHTML generates the HTML for board, tagging any cell listed in
+// violations with an "error-<kind>" class so an htmx-swapped fragment can
+// highlight exactly which cells break a rule. readOnly marks which cells
+// should render as given clues rather than player input; pass nil to treat
+// every cell as editable.
+func renderBoardHTML(board *binairo.Board, readOnly [][]bool, violations []binairo.Violation) string {
+	kindsByCell := make(map[[2]int]map[string]bool)
+	for _, v := range violations {
+		key := [2]int{v.Row, v.Col}
+		if kindsByCell[key] == nil {
+			kindsByCell[key] = make(map[string]bool)
 		}
+		kindsByCell[key][v.Kind] = true
 	}
-	return true
-}
 
-// renderSolvedGridHTML generates the HTML for the solved grid.
-func renderSolvedGridHTML(grid Grid) string {
 	var sb strings.Builder
-	size := len(grid)
+	size := board.Size()
 
 	sb.WriteString(`
 		<div id="solved-grid-container" class="grid-container" style="grid-template-columns: repeat(` + strconv.Itoa(size) + `, 1fr);">`)
 
-	for _, row := range grid {
-		for _, cell := range row {
-			sb.WriteString(`<div class="grid-cell">`)
-			if cell.Value != nil {
-				if cell.ReadOnly {
-					style := "readonly style='background-color: #ffcccc;'"
-					sb.WriteString(fmt.Sprintf(`<input type="text" %s value="%d" />`, style, *cell.Value))
-				} else {
-					sb.WriteString(fmt.Sprintf(`<input type="text" value="%d" />`, *cell.Value))
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			cellClass := "grid-cell"
+			for _, kind := range violationKinds {
+				if kindsByCell[[2]int{i, j}][kind] {
+					cellClass += " error-" + kind
 				}
-			} else {
+			}
+			sb.WriteString(fmt.Sprintf(`<div class="%s">`, cellClass))
+
+			value, ok := board.Value(i, j)
+			switch {
+			case !ok:
 				sb.WriteString(`<input type="text" value="" />`)
+			case readOnly != nil && readOnly[i][j]:
+				style := "readonly style='background-color: #ffcccc;'"
+				sb.WriteString(fmt.Sprintf(`<input type="text" %s value="%d" />`, style, value))
+			default:
+				sb.WriteString(fmt.Sprintf(`<input type="text" value="%d" />`, value))
 			}
 			sb.WriteString(`</div>`)
 		}
@@ -0,0 +1,255 @@
+package binairo
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+var testSizes = []int{4, 6, 8, 10, 12, 14}
+
+// TestSolveKnownPuzzles builds a uniquely-solvable puzzle at each size (seeding
+// math/rand per size so a failure is reproducible) and checks that Solve
+// reconstructs exactly the solution Generate's own uniqueness check already
+// proved was the only one.
+func TestSolveKnownPuzzles(t *testing.T) {
+	for _, size := range testSizes {
+		size := size
+		t.Run(fmt.Sprintf("%dx%d", size, size), func(t *testing.T) {
+			rand.Seed(int64(size))
+
+			puzzle, err := Generate(context.Background(), size, DifficultyMedium)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			solutions, err := puzzle.clone().SolveAll(context.Background(), 2)
+			if err != nil {
+				t.Fatalf("SolveAll: %v", err)
+			}
+			if len(solutions) != 1 {
+				t.Fatalf("expected exactly one solution, got %d", len(solutions))
+			}
+			want := solutions[0]
+
+			got := puzzle.clone()
+			if err := got.Solve(context.Background()); err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+
+			for i := 0; i < size; i++ {
+				for j := 0; j < size; j++ {
+					wv, _ := want.Value(i, j)
+					gv, ok := got.Value(i, j)
+					if !ok || gv != wv {
+						t.Fatalf("cell (%d,%d): got %v, want %d", i, j, gv, wv)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateProperties checks the invariants Generate promises: every
+// board it returns has exactly one solution, and solving that board leaves
+// it free of violations.
+func TestGenerateProperties(t *testing.T) {
+	for _, size := range testSizes {
+		size := size
+		t.Run(fmt.Sprintf("%dx%d", size, size), func(t *testing.T) {
+			puzzle, err := Generate(context.Background(), size, DifficultyHard)
+			if err != nil {
+				t.Fatalf("Generate: %v", err)
+			}
+
+			solutions, err := puzzle.clone().SolveAll(context.Background(), 2)
+			if err != nil {
+				t.Fatalf("SolveAll: %v", err)
+			}
+			if len(solutions) != 1 {
+				t.Fatalf("expected exactly one solution, got %d", len(solutions))
+			}
+
+			solved := puzzle.clone()
+			if err := solved.Solve(context.Background()); err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+			if violations := solved.Violations(); len(violations) != 0 {
+				t.Fatalf("unexpected violations on a solved board: %+v", violations)
+			}
+		})
+	}
+}
+
+// fixedVector is a puzzle/solution pair written out by hand rather than
+// produced by Generate, so TestSolveFixedVectors exercises Solve against an
+// oracle that doesn't share any code with the solver it's checking. -1 in
+// puzzle marks a blank cell.
+type fixedVector struct {
+	size     int
+	puzzle   [][]int
+	solution [][]int
+}
+
+var fixedVectors = []fixedVector{
+	{
+		size: 4,
+		puzzle: [][]int{
+			{0, 0, -1, 1},
+			{-1, 1, 0, 0},
+			{0, -1, 0, 1},
+			{1, 0, 1, -1},
+		},
+		solution: [][]int{
+			{0, 0, 1, 1},
+			{1, 1, 0, 0},
+			{0, 1, 0, 1},
+			{1, 0, 1, 0},
+		},
+	},
+	{
+		size: 6,
+		puzzle: [][]int{
+			{0, 0, 1, -1, 0, 1},
+			{-1, 1, 1, 0, 1, 0},
+			{1, 1, 0, 1, -1, 0},
+			{1, -1, 1, 0, 0, 1},
+			{0, 1, 0, 0, 1, -1},
+			{1, 0, -1, 1, 1, 0},
+		},
+		solution: [][]int{
+			{0, 0, 1, 1, 0, 1},
+			{0, 1, 1, 0, 1, 0},
+			{1, 1, 0, 1, 0, 0},
+			{1, 0, 1, 0, 0, 1},
+			{0, 1, 0, 0, 1, 1},
+			{1, 0, 0, 1, 1, 0},
+		},
+	},
+	{
+		size: 8,
+		puzzle: [][]int{
+			{-1, 0, 1, 0, 1, 1, 0, 1},
+			{0, -1, 0, 1, 1, 0, 1, 0},
+			{1, 0, -1, 1, 0, 1, 0, 0},
+			{0, 1, 1, -1, 1, 0, 0, 1},
+			{1, 1, 0, 1, -1, 0, 1, 0},
+			{1, 0, 1, 0, 0, -1, 0, 1},
+			{0, 1, 0, 0, 1, 0, -1, 1},
+			{1, 0, 0, 1, 0, 1, 1, -1},
+		},
+		solution: [][]int{
+			{0, 0, 1, 0, 1, 1, 0, 1},
+			{0, 1, 0, 1, 1, 0, 1, 0},
+			{1, 0, 1, 1, 0, 1, 0, 0},
+			{0, 1, 1, 0, 1, 0, 0, 1},
+			{1, 1, 0, 1, 0, 0, 1, 0},
+			{1, 0, 1, 0, 0, 1, 0, 1},
+			{0, 1, 0, 0, 1, 0, 1, 1},
+			{1, 0, 0, 1, 0, 1, 1, 0},
+		},
+	},
+}
+
+// TestSolveFixedVectors checks Solve against puzzles whose solution was
+// worked out by hand, not derived from Generate/SolveAll at runtime: a bug
+// shared between propagate and isValid could otherwise pass both ends of a
+// self-consistency check without ever being caught.
+func TestSolveFixedVectors(t *testing.T) {
+	for _, tc := range fixedVectors {
+		tc := tc
+		t.Run(fmt.Sprintf("%dx%d", tc.size, tc.size), func(t *testing.T) {
+			assertValidCompleteGrid(t, tc.size, tc.solution)
+
+			board := New(tc.size)
+			for i := 0; i < tc.size; i++ {
+				for j := 0; j < tc.size; j++ {
+					if tc.puzzle[i][j] >= 0 {
+						board.Set(i, j, tc.puzzle[i][j])
+					}
+				}
+			}
+
+			if err := board.Solve(context.Background()); err != nil {
+				t.Fatalf("Solve: %v", err)
+			}
+			for i := 0; i < tc.size; i++ {
+				for j := 0; j < tc.size; j++ {
+					got, ok := board.Value(i, j)
+					if !ok || got != tc.solution[i][j] {
+						t.Fatalf("cell (%d,%d): got %v, want %d", i, j, got, tc.solution[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+// assertValidCompleteGrid checks grid against Binairo's rules directly -
+// balance, no-triple, distinct lines - without going through propagate or
+// isValid, so it can't share a bug with the code TestSolveFixedVectors is
+// meant to catch.
+func assertValidCompleteGrid(t *testing.T, size int, grid [][]int) {
+	t.Helper()
+
+	rowSigs := make(map[string]bool)
+	for i := 0; i < size; i++ {
+		zeros, ones := 0, 0
+		for j := 0; j < size; j++ {
+			if grid[i][j] == 0 {
+				zeros++
+			} else {
+				ones++
+			}
+			if j >= 2 && grid[i][j] == grid[i][j-1] && grid[i][j-1] == grid[i][j-2] {
+				t.Fatalf("row %d has three consecutive equal values at column %d", i, j)
+			}
+		}
+		if zeros != size/2 || ones != size/2 {
+			t.Fatalf("row %d is not balanced: %d zeros, %d ones", i, zeros, ones)
+		}
+		sig := lineSignature(grid, true, i, size)
+		if rowSigs[sig] {
+			t.Fatalf("row %d duplicates another row", i)
+		}
+		rowSigs[sig] = true
+	}
+
+	colSigs := make(map[string]bool)
+	for j := 0; j < size; j++ {
+		zeros, ones := 0, 0
+		for i := 0; i < size; i++ {
+			if grid[i][j] == 0 {
+				zeros++
+			} else {
+				ones++
+			}
+			if i >= 2 && grid[i][j] == grid[i-1][j] && grid[i-1][j] == grid[i-2][j] {
+				t.Fatalf("column %d has three consecutive equal values at row %d", j, i)
+			}
+		}
+		if zeros != size/2 || ones != size/2 {
+			t.Fatalf("column %d is not balanced: %d zeros, %d ones", j, zeros, ones)
+		}
+		sig := lineSignature(grid, false, j, size)
+		if colSigs[sig] {
+			t.Fatalf("column %d duplicates another column", j)
+		}
+		colSigs[sig] = true
+	}
+}
+
+// lineSignature encodes row i (isRow=true) or column i (isRow=false) as a
+// string for the duplicate-line check in assertValidCompleteGrid.
+func lineSignature(grid [][]int, isRow bool, i, size int) string {
+	buf := make([]byte, size)
+	for k := 0; k < size; k++ {
+		if isRow {
+			buf[k] = byte('0' + grid[i][k])
+		} else {
+			buf[k] = byte('0' + grid[k][i])
+		}
+	}
+	return string(buf)
+}
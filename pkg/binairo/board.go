@@ -0,0 +1,64 @@
+// Package binairo implements a Binairo (also known as Takuzu) puzzle
+// solver, generator, and validator, independent of any particular frontend.
+package binairo
+
+import "errors"
+
+// ErrNoSolution is returned by Solve when the board has no valid completion.
+var ErrNoSolution = errors.New("binairo: board has no solution")
+
+// Board is a size x size Binairo grid. The zero value is not usable; create
+// one with New.
+type Board struct {
+	size  int
+	cells [][]*int
+}
+
+// New returns an empty size x size board. Binairo requires every row and
+// column to hold exactly size/2 zeros and size/2 ones, so size must be even.
+func New(size int) *Board {
+	cells := make([][]*int, size)
+	for i := range cells {
+		cells[i] = make([]*int, size)
+	}
+	return &Board{size: size, cells: cells}
+}
+
+// Size returns the board's side length.
+func (b *Board) Size() int {
+	return b.size
+}
+
+// Set places v (0 or 1) at (row, col).
+func (b *Board) Set(row, col, v int) {
+	b.cells[row][col] = &v
+}
+
+// Clear empties the cell at (row, col).
+func (b *Board) Clear(row, col int) {
+	b.cells[row][col] = nil
+}
+
+// Value reports the value at (row, col) and whether the cell is filled in.
+func (b *Board) Value(row, col int) (int, bool) {
+	c := b.cells[row][col]
+	if c == nil {
+		return 0, false
+	}
+	return *c, true
+}
+
+// clone returns a deep copy of b.
+func (b *Board) clone() *Board {
+	out := New(b.size)
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			if b.cells[i][j] == nil {
+				continue
+			}
+			v := *b.cells[i][j]
+			out.cells[i][j] = &v
+		}
+	}
+	return out
+}
@@ -0,0 +1,159 @@
+package binairo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Difficulty controls how many clues Generate leaves behind in a puzzle.
+type Difficulty string
+
+const (
+	DifficultyEasy   Difficulty = "easy"
+	DifficultyMedium Difficulty = "medium"
+	DifficultyHard   Difficulty = "hard"
+)
+
+// defaultGenerateTimeout bounds how long Generate will spend building and
+// reducing a board on top of whatever deadline ctx already carries.
+const defaultGenerateTimeout = 15 * time.Second
+
+// clueRatioRange returns the [min, max] fraction of cells that should stay
+// filled in for the given difficulty. Unknown values fall back to medium.
+func clueRatioRange(difficulty Difficulty) (min, max float64) {
+	switch difficulty {
+	case DifficultyEasy:
+		return 0.55, 0.65
+	case DifficultyHard:
+		return 0.35, 0.45
+	default:
+		return 0.45, 0.55
+	}
+}
+
+// Generate builds a fully solved random size x size board and then removes
+// cells one at a time, keeping a removal only when SolveAll still proves the
+// board has exactly one solution, until the clue count reaches the range
+// implied by difficulty or every cell has been tried once; in the latter case
+// the returned board keeps more clues than that range. The reduction is
+// bounded by defaultGenerateTimeout on top of ctx, so a hard puzzle on a
+// large grid can't hang the caller.
+func Generate(ctx context.Context, size int, difficulty Difficulty) (*Board, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultGenerateTimeout)
+	defer cancel()
+
+	b := New(size)
+	if err := fillRandomGrid(ctx, b); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("binairo: could not build a solved %dx%d board: %w", size, size, err)
+	}
+
+	minRatio, maxRatio := clueRatioRange(difficulty)
+	targetClues := int(float64(size*size) * (minRatio + rand.Float64()*(maxRatio-minRatio)))
+	cluesToRemove := size*size - targetClues
+
+	coords := make([][2]int, 0, size*size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			coords = append(coords, [2]int{i, j})
+		}
+	}
+	rand.Shuffle(len(coords), func(a, b int) { coords[a], coords[b] = coords[b], coords[a] })
+
+	for _, rc := range coords {
+		if cluesToRemove <= 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		i, j := rc[0], rc[1]
+		removed := *b.cells[i][j]
+		b.cells[i][j] = nil
+		solutions, err := b.SolveAll(ctx, 2)
+		if err != nil {
+			return nil, err
+		}
+		if len(solutions) > 1 {
+			val := removed
+			b.cells[i][j] = &val
+			continue
+		}
+		cluesToRemove--
+	}
+
+	return b, nil
+}
+
+// fillRandomGrid fills an already-allocated empty board with a random valid
+// solution. Like SolveAll, it runs propagate before every branch so it fails
+// fast instead of falling back to plain backtracking, and it checks ctx on
+// every recursive step so a caller's deadline actually bounds this step too,
+// not just the reduction loop that follows it. It tries 0/1 in a random
+// order at each branch so repeated calls produce different boards.
+func fillRandomGrid(ctx context.Context, b *Board) error {
+	working := b.clone()
+	var solution *Board
+	var ctxErr error
+
+	var search func() bool
+	search = func() bool {
+		if err := ctx.Err(); err != nil {
+			ctxErr = err
+			return true
+		}
+
+		forced, contradiction := propagate(working)
+		defer undoForced(working, forced)
+		if contradiction {
+			return false
+		}
+
+		row, col, ok := mostConstrainedCell(working)
+		if !ok {
+			solution = working.clone()
+			return true
+		}
+
+		order := [2]int{0, 1}
+		if rand.Intn(2) == 1 {
+			order[0], order[1] = order[1], order[0]
+		}
+		for _, val := range order {
+			v := val
+			if isValid(working, row, col, v) {
+				working.cells[row][col] = &v
+				done := search()
+				working.cells[row][col] = nil
+				if done {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	search()
+
+	if ctxErr != nil {
+		return ctxErr
+	}
+	if solution == nil {
+		return ErrNoSolution
+	}
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			v, _ := solution.Value(i, j)
+			val := v
+			b.cells[i][j] = &val
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package binairo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestGenerateRespectsDeadline stresses the sizes a /generate request is
+// actually allowed to ask for (up to maxGridSize in main.go) at the hardest
+// difficulty, the combination most likely to run long. It asserts Generate
+// never overruns a short deadline by more than a small margin, regardless of
+// whether it finishes in time or reports ctx's error - both fillRandomGrid
+// and the reduction loop must check ctx often enough to make that true.
+func TestGenerateRespectsDeadline(t *testing.T) {
+	const deadline = 50 * time.Millisecond
+	const grace = 2 * time.Second
+
+	for _, size := range []int{16, 18, 20} {
+		size := size
+		t.Run(fmt.Sprintf("%dx%d", size, size), func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), deadline)
+			defer cancel()
+
+			start := time.Now()
+			_, err := Generate(ctx, size, DifficultyHard)
+			elapsed := time.Since(start)
+
+			if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if elapsed > deadline+grace {
+				t.Fatalf("Generate took %v to respect a %v deadline", elapsed, deadline)
+			}
+		})
+	}
+}
@@ -0,0 +1,418 @@
+package binairo
+
+import "context"
+
+// Solve finds a single solution for b and fills in every empty cell to
+// match it, leaving already-set cells untouched. It returns ErrNoSolution if
+// no completion exists, or ctx.Err() if ctx is cancelled first.
+func (b *Board) Solve(ctx context.Context) error {
+	solutions, err := b.SolveAll(ctx, 1)
+	if err != nil {
+		return err
+	}
+	if len(solutions) == 0 {
+		return ErrNoSolution
+	}
+	solved := solutions[0]
+	for i := 0; i < b.size; i++ {
+		for j := 0; j < b.size; j++ {
+			v, ok := solved.Value(i, j)
+			if ok {
+				b.cells[i][j] = &v
+			}
+		}
+	}
+	return nil
+}
+
+// SolveAll enumerates up to limit distinct solutions for b without
+// modifying b, returning deep copies of each. It is the primitive both
+// Solve and Generate's uniqueness check are built on.
+//
+// At each step it first runs propagate to apply every forced move it can
+// deduce, then branches on an empty cell in whichever row or column has the
+// fewest empty cells left, which fails faster than a row-major scan.
+func (b *Board) SolveAll(ctx context.Context, limit int) ([]*Board, error) {
+	working := b.clone()
+	var solutions []*Board
+	var ctxErr error
+
+	var search func() bool
+	search = func() bool {
+		if err := ctx.Err(); err != nil {
+			ctxErr = err
+			return true
+		}
+
+		forced, contradiction := propagate(working)
+		defer undoForced(working, forced)
+		if contradiction {
+			return false
+		}
+
+		row, col, ok := mostConstrainedCell(working)
+		if !ok {
+			solutions = append(solutions, working.clone())
+			return len(solutions) >= limit
+		}
+
+		for _, val := range []int{0, 1} {
+			v := val
+			if isValid(working, row, col, v) {
+				working.cells[row][col] = &v
+				done := search()
+				working.cells[row][col] = nil
+				if done {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	search()
+
+	if ctxErr != nil {
+		return nil, ctxErr
+	}
+	return solutions, nil
+}
+
+// point identifies a single board cell by row and column.
+type point struct{ row, col int }
+
+// propagate applies the three Takuzu deductions (triple avoidance, line
+// balance, and line uniqueness) until none of them produce any more forced
+// moves. It returns every cell it set, so the caller can undo them when it
+// backtracks, and whether a contradiction (a line that can't legally be
+// completed) was found.
+func propagate(b *Board) (forced []point, contradiction bool) {
+	size := b.size
+	for {
+		changed := false
+
+		for i := 0; i < size; i++ {
+			for j := 0; j < size; j++ {
+				if b.cells[i][j] != nil {
+					continue
+				}
+				v, ok := forcedByTriple(b, i, j)
+				if !ok {
+					continue
+				}
+				if !isValid(b, i, j, v) {
+					return forced, true
+				}
+				val := v
+				b.cells[i][j] = &val
+				forced = append(forced, point{i, j})
+				changed = true
+			}
+		}
+
+		for _, isRow := range [2]bool{true, false} {
+			for i := 0; i < size; i++ {
+				newlyForced, contra := applyLineBalance(b, isRow, i)
+				forced = append(forced, newlyForced...)
+				if contra {
+					return forced, true
+				}
+				changed = changed || len(newlyForced) > 0
+			}
+			newlyForced, contra := applyLineUniqueness(b, isRow)
+			forced = append(forced, newlyForced...)
+			if contra {
+				return forced, true
+			}
+			changed = changed || len(newlyForced) > 0
+		}
+
+		if !changed {
+			return forced, false
+		}
+	}
+}
+
+// undoForced resets every cell in forced back to empty, unwinding the moves
+// a propagate call made so the caller's backtracking sees its own state.
+func undoForced(b *Board, forced []point) {
+	for _, p := range forced {
+		b.cells[p.row][p.col] = nil
+	}
+}
+
+// forcedByTriple reports the value triple avoidance forces into the empty
+// cell at (row, col), looking at both its row and its column.
+func forcedByTriple(b *Board, row, col int) (int, bool) {
+	if v, ok := forcedInLine(line(b, true, row), col); ok {
+		return v, true
+	}
+	if v, ok := forcedInLine(line(b, false, col), row); ok {
+		return v, true
+	}
+	return 0, false
+}
+
+// forcedInLine checks the three triple-avoidance patterns around pos in a
+// single row or column's values (nil meaning empty).
+func forcedInLine(vals []*int, pos int) (int, bool) {
+	size := len(vals)
+	if pos >= 2 && vals[pos-2] != nil && vals[pos-1] != nil && *vals[pos-2] == *vals[pos-1] {
+		return 1 - *vals[pos-1], true
+	}
+	if pos >= 1 && pos+1 < size && vals[pos-1] != nil && vals[pos+1] != nil && *vals[pos-1] == *vals[pos+1] {
+		return 1 - *vals[pos-1], true
+	}
+	if pos+2 < size && vals[pos+1] != nil && vals[pos+2] != nil && *vals[pos+1] == *vals[pos+2] {
+		return 1 - *vals[pos+1], true
+	}
+	return 0, false
+}
+
+// applyLineBalance forces every empty cell in row/column i to 1-v once that
+// line already holds size/2 occurrences of v, returning the cells it set.
+func applyLineBalance(b *Board, isRow bool, i int) (forced []point, contradiction bool) {
+	size := b.size
+	vals := line(b, isRow, i)
+	for _, v := range []int{0, 1} {
+		if countValues(vals, v) != size/2 {
+			continue
+		}
+		for k := 0; k < size; k++ {
+			if vals[k] != nil {
+				continue
+			}
+			row, col := rowCol(isRow, i, k)
+			other := 1 - v
+			if !isValid(b, row, col, other) {
+				return forced, true
+			}
+			val := other
+			b.cells[row][col] = &val
+			forced = append(forced, point{row, col})
+			vals[k] = &val
+		}
+	}
+	return forced, false
+}
+
+// applyLineUniqueness forces the last empty cell of an otherwise-complete
+// row/column to whichever value keeps it from duplicating another
+// already-completed row/column.
+func applyLineUniqueness(b *Board, isRow bool) (forced []point, contradiction bool) {
+	size := b.size
+	completed := make(map[string]bool)
+	allVals := make([][]*int, size)
+	for i := 0; i < size; i++ {
+		allVals[i] = line(b, isRow, i)
+		if countValues(allVals[i], 0)+countValues(allVals[i], 1) == size {
+			completed[signature(allVals[i])] = true
+		}
+	}
+
+	for i := 0; i < size; i++ {
+		vals := allVals[i]
+		emptyPos, emptyCount := -1, 0
+		for k, p := range vals {
+			if p == nil {
+				emptyPos, emptyCount = k, emptyCount+1
+			}
+		}
+		if emptyCount != 1 {
+			continue
+		}
+		for _, v := range []int{0, 1} {
+			vals[emptyPos] = &v
+			duplicate := completed[signature(vals)]
+			vals[emptyPos] = nil
+			if !duplicate {
+				continue
+			}
+			other := 1 - v
+			row, col := rowCol(isRow, i, emptyPos)
+			if !isValid(b, row, col, other) {
+				return forced, true
+			}
+			val := other
+			b.cells[row][col] = &val
+			forced = append(forced, point{row, col})
+		}
+	}
+	return forced, false
+}
+
+// mostConstrainedCell returns an empty cell belonging to whichever row or
+// column currently has the fewest empty cells, so the solver branches where
+// it is most likely to hit a contradiction quickly. ok is false once the
+// board has no empty cells left.
+func mostConstrainedCell(b *Board) (row, col int, ok bool) {
+	size := b.size
+	rowEmpty := make([]int, size)
+	colEmpty := make([]int, size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if b.cells[i][j] == nil {
+				rowEmpty[i]++
+				colEmpty[j]++
+			}
+		}
+	}
+
+	best := size + 1
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if b.cells[i][j] != nil {
+				continue
+			}
+			constraint := rowEmpty[i]
+			if colEmpty[j] < constraint {
+				constraint = colEmpty[j]
+			}
+			if constraint < best {
+				best, row, col, ok = constraint, i, j, true
+			}
+		}
+	}
+	return row, col, ok
+}
+
+// line returns the values along row i (isRow=true) or column i (isRow=false),
+// with nil standing in for an empty cell.
+func line(b *Board, isRow bool, i int) []*int {
+	size := b.size
+	vals := make([]*int, size)
+	for k := 0; k < size; k++ {
+		row, col := rowCol(isRow, i, k)
+		vals[k] = b.cells[row][col]
+	}
+	return vals
+}
+
+// rowCol maps a (line index, position) pair back to board coordinates for a
+// row (isRow=true) or a column (isRow=false).
+func rowCol(isRow bool, i, k int) (row, col int) {
+	if isRow {
+		return i, k
+	}
+	return k, i
+}
+
+// countValues counts how many non-empty entries in vals equal v.
+func countValues(vals []*int, v int) int {
+	count := 0
+	for _, p := range vals {
+		if p != nil && *p == v {
+			count++
+		}
+	}
+	return count
+}
+
+// signature encodes a line's values as a string ('_' for empty) so two
+// completed lines can be compared for the line-uniqueness deduction.
+func signature(vals []*int) string {
+	buf := make([]byte, len(vals))
+	for i, p := range vals {
+		if p == nil {
+			buf[i] = '_'
+		} else {
+			buf[i] = byte('0' + *p)
+		}
+	}
+	return string(buf)
+}
+
+// isValid reports whether placing value at (row, col) keeps every
+// completed or partial row and column within Binairo's balance, no-triple,
+// and line-uniqueness rules. It is the sole legality gate every branch and
+// deduction in this package goes through, so this is also the only place
+// any of the three rules is actually enforced.
+func isValid(b *Board, row, col, value int) bool {
+	size := b.size
+	rowValues := make([]int, size)
+	colValues := make([]int, size)
+
+	for i := 0; i < size; i++ {
+		if b.cells[row][i] != nil {
+			rowValues[i] = *b.cells[row][i]
+		} else {
+			rowValues[i] = -1
+		}
+		if b.cells[i][col] != nil {
+			colValues[i] = *b.cells[i][col]
+		} else {
+			colValues[i] = -1
+		}
+	}
+
+	rowValues[col] = value
+	colValues[row] = value
+
+	if countOccurrences(rowValues, value) > size/2 || countOccurrences(colValues, value) > size/2 {
+		return false
+	}
+
+	if (col > 1 && isSame(rowValues[col-2:col+1], []int{value, value, value})) ||
+		(col > 0 && col < size-1 && isSame(rowValues[col-1:col+2], []int{value, value, value})) ||
+		(col < size-2 && isSame(rowValues[col:col+3], []int{value, value, value})) ||
+		(row > 1 && isSame(colValues[row-2:row+1], []int{value, value, value})) ||
+		(row > 0 && row < size-1 && isSame(colValues[row-1:row+2], []int{value, value, value})) ||
+		(row < size-2 && isSame(colValues[row:row+3], []int{value, value, value})) {
+		return false
+	}
+
+	if countOccurrences(rowValues, -1) == 0 && lineDuplicates(b, true, row, rowValues) {
+		return false
+	}
+	if countOccurrences(colValues, -1) == 0 && lineDuplicates(b, false, col, colValues) {
+		return false
+	}
+
+	return true
+}
+
+// lineDuplicates reports whether vals, a just-completed row (isRow=true) or
+// column at index i, exactly matches another already-completed row/column.
+func lineDuplicates(b *Board, isRow bool, i int, vals []int) bool {
+	size := b.size
+	for other := 0; other < size; other++ {
+		if other == i {
+			continue
+		}
+		otherVals := line(b, isRow, other)
+		same := true
+		for k, v := range vals {
+			if otherVals[k] == nil || *otherVals[k] != v {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// countOccurrences counts the occurrences of a value in a slice.
+func countOccurrences(slice []int, value int) int {
+	count := 0
+	for _, v := range slice {
+		if v == value {
+			count++
+		}
+	}
+	return count
+}
+
+// isSame checks if two slices are equal in terms of elements and their order.
+func isSame(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
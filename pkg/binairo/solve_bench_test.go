@@ -0,0 +1,19 @@
+package binairo
+
+import (
+	"context"
+	"testing"
+)
+
+func benchmarkSolve(b *testing.B, size int) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		board := New(size)
+		b.StartTimer()
+		board.Solve(context.Background())
+	}
+}
+
+func BenchmarkSolve6x6(b *testing.B)   { benchmarkSolve(b, 6) }
+func BenchmarkSolve10x10(b *testing.B) { benchmarkSolve(b, 10) }
+func BenchmarkSolve14x14(b *testing.B) { benchmarkSolve(b, 14) }
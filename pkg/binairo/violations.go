@@ -0,0 +1,74 @@
+package binairo
+
+// Violation describes a single rule breach at one board cell.
+type Violation struct {
+	Row, Col int
+	Kind     string
+}
+
+// Violation kinds, in the order Violations applies them.
+const (
+	ViolationTriple        = "triple"
+	ViolationBalance       = "balance"
+	ViolationDuplicateLine = "duplicate-line"
+)
+
+// Violations walks b once and reports every rule breach it finds, rather
+// than stopping at the first. It only looks at cells that are filled in, so
+// a partially solved board gets useful feedback instead of being rejected
+// outright, and returns nil once a board is fully and correctly completed.
+func (b *Board) Violations() []Violation {
+	size := b.size
+	var violations []Violation
+
+	for _, isRow := range [2]bool{true, false} {
+		for i := 0; i < size; i++ {
+			vals := line(b, isRow, i)
+
+			for k := 0; k+2 < size; k++ {
+				if vals[k] != nil && vals[k+1] != nil && vals[k+2] != nil &&
+					*vals[k] == *vals[k+1] && *vals[k+1] == *vals[k+2] {
+					for _, p := range [3]int{k, k + 1, k + 2} {
+						row, col := rowCol(isRow, i, p)
+						violations = append(violations, Violation{row, col, ViolationTriple})
+					}
+				}
+			}
+
+			for _, v := range []int{0, 1} {
+				if countValues(vals, v) <= size/2 {
+					continue
+				}
+				for k := 0; k < size; k++ {
+					if vals[k] != nil && *vals[k] == v {
+						row, col := rowCol(isRow, i, k)
+						violations = append(violations, Violation{row, col, ViolationBalance})
+					}
+				}
+			}
+		}
+
+		indicesBySignature := make(map[string][]int)
+		for i := 0; i < size; i++ {
+			vals := line(b, isRow, i)
+			if countValues(vals, 0)+countValues(vals, 1) != size {
+				continue
+			}
+			sig := signature(vals)
+			indicesBySignature[sig] = append(indicesBySignature[sig], i)
+		}
+		for _, indices := range indicesBySignature {
+			if len(indices) < 2 {
+				continue
+			}
+			for _, i := range indices {
+				for k := 0; k < size; k++ {
+					row, col := rowCol(isRow, i, k)
+					violations = append(violations, Violation{row, col, ViolationDuplicateLine})
+				}
+			}
+		}
+	}
+
+	return violations
+}